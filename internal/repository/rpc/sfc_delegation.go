@@ -21,29 +21,175 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// callOptsAt returns a bind.CallOpts pinned to the given block, or the default "latest" call
+// options when block is nil. A pure block hash reference is resolved to its block number first,
+// since bind.CallOpts can only pin by number.
+func (chain *ChainBridge) callOptsAt(block *rpc.BlockNumberOrHash) (*bind.CallOpts, error) {
+	if block == nil {
+		return chain.DefaultCallOpts(), nil
+	}
+
+	num, err := chain.blockNumberFromRef(block)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := *chain.DefaultCallOpts()
+	opts.BlockNumber = num
+	return &opts, nil
+}
+
+// blockNumberFromRef resolves an EIP-1898 style block reference into a concrete block number, as
+// needed by APIs such as bind.CallOpts.BlockNumber or eth.CallContract that only accept one.
+// "latest"/"pending" resolve to nil to preserve the existing tip-of-chain behavior; "safe" and
+// "finalized" are resolved to their current concrete block number via HeaderByNumber.
+func (chain *ChainBridge) blockNumberFromRef(block *rpc.BlockNumberOrHash) (*big.Int, error) {
+	if block == nil {
+		return nil, nil
+	}
+
+	if num, ok := block.Number(); ok {
+		value, needsHeader := resolveBlockNumberTag(num)
+		if !needsHeader {
+			return value, nil
+		}
+
+		header, err := chain.eth.HeaderByNumber(context.Background(), big.NewInt(num.Int64()))
+		if err != nil {
+			return nil, fmt.Errorf("block tag %s not available; %s", num.String(), err.Error())
+		}
+		return header.Number, nil
+	}
+
+	hash, ok := block.Hash()
+	if !ok {
+		return nil, fmt.Errorf("invalid block reference")
+	}
+
+	header, err := chain.eth.HeaderByHash(context.Background(), hash)
+	if err != nil {
+		return nil, fmt.Errorf("block %s not found; %s", hash.String(), err.Error())
+	}
+	return header.Number, nil
+}
+
+// resolveBlockNumberTag classifies a non-pinned rpc.BlockNumber. "latest"/"pending" resolve to a
+// nil block number directly; "safe"/"finalized" need a HeaderByNumber round trip to translate to
+// a concrete number; any explicit number resolves to itself.
+func resolveBlockNumberTag(num rpc.BlockNumber) (value *big.Int, needsHeader bool) {
+	switch num {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return nil, false
+	case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		return nil, true
+	default:
+		return big.NewInt(num.Int64()), false
+	}
+}
+
+// epochBlockScanWindow bounds how many blocks a single EpochBlock FilterLogs call covers.
+const epochBlockScanWindow = 100_000
+
+// EpochBlock resolves an SFC epoch ID to the block number in which the epoch was sealed, scanning
+// backward from the chain head in epochBlockScanWindow-sized chunks.
+func (chain *ChainBridge) EpochBlock(epoch *big.Int) (uint64, error) {
+	ev, ok := chain.SfcAbi().Events["NewEpoch"]
+	if !ok {
+		return 0, fmt.Errorf("SFC ABI does not expose the NewEpoch event")
+	}
+
+	head, err := chain.eth.BlockNumber(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("epoch %d seal block not available; %s", epoch.Uint64(), err.Error())
+	}
+
+	for to := head; ; {
+		from := uint64(0)
+		if to > epochBlockScanWindow {
+			from = to - epochBlockScanWindow
+		}
+
+		logs, err := chain.eth.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{chain.sfcConfig.SFCContract},
+			Topics:    [][]common.Hash{{ev.ID}, {common.BigToHash(epoch)}},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("epoch %d seal block not available; %s", epoch.Uint64(), err.Error())
+		}
+		if len(logs) > 0 {
+			return logs[len(logs)-1].BlockNumber, nil
+		}
+		if from == 0 {
+			return 0, fmt.Errorf("epoch %d not sealed yet", epoch.Uint64())
+		}
+		to = from - 1
+	}
+}
+
 // AmountStaked returns the current amount at stake for the given staker address and target validator
 func (chain *ChainBridge) AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error) {
+	return chain.AmountStakedAt(addr, valID, nil)
+}
+
+// AmountStakedAt returns the amount at stake for the given staker address and target validator
+// as of the given block; a nil block keeps the current "latest" behavior.
+func (chain *ChainBridge) AmountStakedAt(addr *common.Address, valID *big.Int, block *rpc.BlockNumberOrHash) (*big.Int, error) {
 	// keep track of the operation
 	chain.log.Debugf("verifying amount staked by %s to %d", addr.String(), valID.Uint64())
-	return chain.SfcContract().GetStake(chain.DefaultCallOpts(), *addr, valID)
+
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		return nil, err
+	}
+	return chain.SfcContract().GetStake(opts, *addr, valID)
 }
 
 // AmountStakeLocked returns the current locked amount at stake for the given staker address and target validator.
 func (chain *ChainBridge) AmountStakeLocked(addr *common.Address, valID *big.Int) (*big.Int, error) {
-	return chain.SfcContract().GetLockedStake(chain.DefaultCallOpts(), *addr, valID)
+	return chain.AmountStakeLockedAt(addr, valID, nil)
+}
+
+// AmountStakeLockedAt returns the locked amount at stake for the given staker address and target
+// validator, as of the given block (nil for "latest").
+func (chain *ChainBridge) AmountStakeLockedAt(addr *common.Address, valID *big.Int, block *rpc.BlockNumberOrHash) (*big.Int, error) {
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		return nil, err
+	}
+	return chain.SfcContract().GetLockedStake(opts, *addr, valID)
 }
 
 // AmountStakeUnlocked returns the current unlocked amount at stake for the given staker address and target validator.
 func (chain *ChainBridge) AmountStakeUnlocked(addr *common.Address, valID *big.Int) (*big.Int, error) {
-	return chain.SfcContract().GetUnlockedStake(chain.DefaultCallOpts(), *addr, valID)
+	return chain.AmountStakeUnlockedAt(addr, valID, nil)
+}
+
+// AmountStakeUnlockedAt returns the unlocked amount at stake for the given staker address and
+// target validator, as of the given block (nil for "latest").
+func (chain *ChainBridge) AmountStakeUnlockedAt(addr *common.Address, valID *big.Int, block *rpc.BlockNumberOrHash) (*big.Int, error) {
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		return nil, err
+	}
+	return chain.SfcContract().GetUnlockedStake(opts, *addr, valID)
 }
 
 // StakeUnlockPenalty returns the expected penalty of a premature stake unlock.
 func (chain *ChainBridge) StakeUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (*big.Int, error) {
+	return chain.StakeUnlockPenaltyAt(addr, valID, amount, nil)
+}
+
+// StakeUnlockPenaltyAt returns the expected penalty of a premature stake unlock, as of the given
+// block (nil for "latest").
+func (chain *ChainBridge) StakeUnlockPenaltyAt(addr *common.Address, valID *big.Int, amount *big.Int, block *rpc.BlockNumberOrHash) (*big.Int, error) {
 	// pack call data
 	cd, err := chain.SfcAbi().Pack("unlockStake", valID, amount)
 	if err != nil {
@@ -51,12 +197,18 @@ func (chain *ChainBridge) StakeUnlockPenalty(addr *common.Address, valID *big.In
 		return nil, err
 	}
 
+	blockNumber, err := chain.blockNumberFromRef(block)
+	if err != nil {
+		chain.log.Errorf("penalty for unlocking %d of %s to %d not available; %s", amount.Uint64(), addr.String(), valID.Uint64(), err.Error())
+		return nil, err
+	}
+
 	// make the UnlockStake call as a view call to get the penalty value
 	data, err := chain.eth.CallContract(context.Background(), ethereum.CallMsg{
 		From: *addr,
 		To:   &chain.sfcConfig.SFCContract,
 		Data: cd,
-	}, nil)
+	}, blockNumber)
 	if err != nil {
 		chain.log.Errorf("penalty for unlocking %d of %s to %d not available; %s", amount.Uint64(), addr.String(), valID.Uint64(), err.Error())
 		return nil, err
@@ -74,6 +226,12 @@ func (chain *ChainBridge) StakeUnlockPenalty(addr *common.Address, valID *big.In
 
 // PendingRewards returns a detail of delegation rewards waiting to be claimed for the given delegation.
 func (chain *ChainBridge) PendingRewards(addr *common.Address, valID *big.Int) (*types.PendingRewards, error) {
+	return chain.PendingRewardsAt(addr, valID, nil)
+}
+
+// PendingRewardsAt returns a detail of delegation rewards waiting to be claimed for the given
+// delegation, as of the given block (nil for "latest").
+func (chain *ChainBridge) PendingRewardsAt(addr *common.Address, valID *big.Int, block *rpc.BlockNumberOrHash) (*types.PendingRewards, error) {
 	// prep the empty value
 	pr := types.PendingRewards{
 		Address: *addr,
@@ -81,8 +239,14 @@ func (chain *ChainBridge) PendingRewards(addr *common.Address, valID *big.Int) (
 		Amount:  hexutil.Big{},
 	}
 
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		chain.log.Criticalf("can not calculate pending rewards of %s to %d; %s", addr.String(), valID.Uint64(), err.Error())
+		return &pr, nil
+	}
+
 	// get the pending rewards amount
-	amo, err := chain.SfcContract().PendingRewards(chain.DefaultCallOpts(), *addr, valID)
+	amo, err := chain.SfcContract().PendingRewards(opts, *addr, valID)
 	if err != nil {
 		chain.log.Criticalf("can not calculate pending rewards of %s to %d; %s", addr.String(), valID.Uint64(), err.Error())
 		return &pr, nil
@@ -94,7 +258,13 @@ func (chain *ChainBridge) PendingRewards(addr *common.Address, valID *big.Int) (
 }
 
 // DelegationLock returns delegation lock information using SFC contract binding.
-func (chain *ChainBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error) {
+func (chain *ChainBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (*types.DelegationLock, error) {
+	return chain.DelegationLockAt(addr, valID, nil)
+}
+
+// DelegationLockAt returns delegation lock information using SFC contract binding, as of the
+// given block (nil for "latest").
+func (chain *ChainBridge) DelegationLockAt(addr *common.Address, valID *hexutil.Big, block *rpc.BlockNumberOrHash) (dll *types.DelegationLock, err error) {
 	// recover from panic here
 	defer func() {
 		if r := recover(); r != nil {
@@ -103,8 +273,14 @@ func (chain *ChainBridge) DelegationLock(addr *common.Address, valID *hexutil.Bi
 		}
 	}()
 
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		chain.log.Errorf("delegation lock query failed; %v", err)
+		return nil, err
+	}
+
 	// get staker locking detail
-	lock, err := chain.SfcContract().GetLockupInfo(chain.DefaultCallOpts(), *addr, valID.ToInt())
+	lock, err := chain.SfcContract().GetLockupInfo(opts, *addr, valID.ToInt())
 	if err != nil {
 		chain.log.Errorf("delegation lock query failed; %v", err)
 		return nil, err
@@ -128,6 +304,12 @@ func (chain *ChainBridge) DelegationLock(addr *common.Address, valID *hexutil.Bi
 // DelegationOutstandingSCoin returns the amount of tokens for the delegation
 // identified by the delegator address and the stakerId.
 func (chain *ChainBridge) DelegationOutstandingSCoin(addr *common.Address, valID *big.Int) (*big.Int, error) {
+	return chain.DelegationOutstandingSCoinAt(addr, valID, nil)
+}
+
+// DelegationOutstandingSCoinAt returns the amount of tokens for the delegation identified by the
+// delegator address and the stakerId, as of the given block (nil for "latest").
+func (chain *ChainBridge) DelegationOutstandingSCoinAt(addr *common.Address, valID *big.Int, block *rpc.BlockNumberOrHash) (*big.Int, error) {
 	// log action
 	chain.log.Debugf("checking outstanding of %s to %d", addr.String(), valID.Uint64())
 
@@ -138,13 +320,24 @@ func (chain *ChainBridge) DelegationOutstandingSCoin(addr *common.Address, valID
 		return nil, err
 	}
 
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		return nil, err
+	}
+
 	// get the amount of outstanding
-	return contract.OutstandingSCoin(chain.DefaultCallOpts(), *addr, valID)
+	return contract.OutstandingSCoin(opts, *addr, valID)
 }
 
 // DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
 // for a delegation identified by the address and staker id.
 func (chain *ChainBridge) DelegationTokenizerUnlocked(addr *common.Address, valID *big.Int) (bool, error) {
+	return chain.DelegationTokenizerUnlockedAt(addr, valID, nil)
+}
+
+// DelegationTokenizerUnlockedAt returns the status of SFC Tokenizer lock for a delegation
+// identified by the address and staker id, as of the given block (nil for "latest").
+func (chain *ChainBridge) DelegationTokenizerUnlockedAt(addr *common.Address, valID *big.Int, block *rpc.BlockNumberOrHash) (bool, error) {
 	// log action
 	chain.log.Debugf("checking SFC tokenizer lock of %s to %d", addr.String(), valID.Uint64())
 
@@ -155,8 +348,13 @@ func (chain *ChainBridge) DelegationTokenizerUnlocked(addr *common.Address, valI
 		return false, err
 	}
 
+	opts, err := chain.callOptsAt(block)
+	if err != nil {
+		return false, err
+	}
+
 	// get the lock status
-	lock, err := contract.AllowedToWithdrawStake(chain.DefaultCallOpts(), *addr, valID)
+	lock, err := contract.AllowedToWithdrawStake(opts, *addr, valID)
 	if err != nil {
 		chain.log.Criticalf("failed to get SFC Tokenizer lock status of %s to %d; %s", addr.String(), valID.Uint64(), err.Error())
 		return false, err
@@ -0,0 +1,313 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"galaxy-graphql/internal/repository/rpc/contracts"
+	"galaxy-graphql/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// errSfcSnapshotFieldFailed marks a DelegationSnapshot field whose batched call reverted or
+// could not be decoded.
+var errSfcSnapshotFieldFailed = errors.New("delegation snapshot field not available")
+
+// sfcSnapshotDefaultBatchSize is the batch size DelegationSnapshots falls back to when called
+// with batchSize <= 0.
+const sfcSnapshotDefaultBatchSize = 25
+
+// sfcSnapshotCallsPerPair is the number of eth_call batch elements issued per delegation pair
+// (stake, locked stake, unlocked stake, pending rewards, lock info, outstanding sCoin, tokenizer lock).
+const sfcSnapshotCallsPerPair = 7
+
+// DelegationSnapshot pulls the full staking/delegation detail for a single delegator/validator
+// pair in one batched RPC round trip.
+func (chain *ChainBridge) DelegationSnapshot(addr *common.Address, valID *big.Int) (*types.DelegationSnapshot, error) {
+	snaps, err := chain.DelegationSnapshots([]types.DelegationKey{{
+		Address:     *addr,
+		ValidatorID: hexutil.Big(*valID),
+	}}, sfcSnapshotDefaultBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	return &snaps[0], nil
+}
+
+// DelegationSnapshots resolves staking/delegation detail for a set of delegator/validator pairs
+// in as few batched eth_call requests as possible. batchSize caps how many pairs go into a single
+// batch; batchSize <= 0 falls back to sfcSnapshotDefaultBatchSize.
+func (chain *ChainBridge) DelegationSnapshots(pairs []types.DelegationKey, batchSize int) ([]types.DelegationSnapshot, error) {
+	if batchSize <= 0 {
+		batchSize = sfcSnapshotDefaultBatchSize
+	}
+
+	out := make([]types.DelegationSnapshot, len(pairs))
+	for i, key := range pairs {
+		out[i].Key = key
+	}
+
+	for _, chunk := range chunkDelegationKeyRanges(len(pairs), batchSize) {
+		if err := chain.delegationSnapshotBatch(pairs[chunk[0]:chunk[1]], out[chunk[0]:chunk[1]]); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// chunkDelegationKeyRanges splits [0, total) into consecutive [start, end) ranges of at most
+// size elements each.
+func chunkDelegationKeyRanges(total, size int) [][2]int {
+	if size <= 0 {
+		size = sfcSnapshotDefaultBatchSize
+	}
+
+	ranges := make([][2]int, 0, (total+size-1)/size)
+	for offset := 0; offset < total; offset += size {
+		end := offset + size
+		if end > total {
+			end = total
+		}
+		ranges = append(ranges, [2]int{offset, end})
+	}
+	return ranges
+}
+
+// sfcSnapshotField describes one eth_call packed into a delegation snapshot batch.
+type sfcSnapshotField struct {
+	to     common.Address
+	pack   func() ([]byte, error)
+	assign func(snap *types.DelegationSnapshot, data []byte) error
+}
+
+// delegationSnapshotBatch dispatches a single BatchCallContext covering every field of every
+// pair in the given slice, and folds the results back into the matching snapshot.
+func (chain *ChainBridge) delegationSnapshotBatch(pairs []types.DelegationKey, snaps []types.DelegationSnapshot) error {
+	fields := make([]sfcSnapshotField, 0, len(pairs)*sfcSnapshotCallsPerPair)
+	for i := range pairs {
+		fields = append(fields, chain.sfcSnapshotFields(&pairs[i], &snaps[i])...)
+	}
+
+	elems := make([]rpc.BatchElem, 0, len(fields))
+	results := make([]hexutil.Bytes, len(fields))
+	elemField := make([]int, 0, len(fields))
+	for i, f := range fields {
+		cd, err := f.pack()
+		if err != nil {
+			snap := &snaps[i/sfcSnapshotCallsPerPair]
+			chain.log.Debugf("delegation snapshot field could not be packed; %s", err.Error())
+			if aerr := f.assign(snap, nil); aerr != nil {
+				chain.log.Debugf("delegation snapshot field failed; %s", aerr.Error())
+			}
+			continue
+		}
+
+		elems = append(elems, rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{toCallArg(f.to, cd), "latest"},
+			Result: &results[i],
+		})
+		elemField = append(elemField, i)
+	}
+
+	if len(elems) == 0 {
+		return nil
+	}
+
+	if err := chain.eth.Client().BatchCallContext(context.Background(), elems); err != nil {
+		chain.log.Errorf("delegation snapshot batch of %d call(s) failed; %s", len(elems), err.Error())
+		return err
+	}
+
+	for k, elem := range elems {
+		i := elemField[k]
+		snap := &snaps[i/sfcSnapshotCallsPerPair]
+
+		if elem.Error != nil {
+			if aerr := fields[i].assign(snap, nil); aerr != nil {
+				chain.log.Debugf("delegation snapshot field reverted; %s", elem.Error.Error())
+			}
+			continue
+		}
+		if err := fields[i].assign(snap, results[i]); err != nil {
+			chain.log.Debugf("delegation snapshot field decode failed; %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// sfcSnapshotFields builds the set of batch fields for a single delegator/validator pair.
+func (chain *ChainBridge) sfcSnapshotFields(key *types.DelegationKey, snap *types.DelegationSnapshot) []sfcSnapshotField {
+	addr := key.Address
+	valID := key.ValidatorID.ToInt()
+
+	return []sfcSnapshotField{
+		{
+			to:   chain.sfcConfig.SFCContract,
+			pack: func() ([]byte, error) { return chain.SfcAbi().Pack("getStake", addr, valID) },
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				return decodeSfcBigInt(chain, "getStake", data, &s.Staked, &s.Errors.Staked)
+			},
+		},
+		{
+			to:   chain.sfcConfig.SFCContract,
+			pack: func() ([]byte, error) { return chain.SfcAbi().Pack("getLockedStake", addr, valID) },
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				return decodeSfcBigInt(chain, "getLockedStake", data, &s.Locked, &s.Errors.Locked)
+			},
+		},
+		{
+			to:   chain.sfcConfig.SFCContract,
+			pack: func() ([]byte, error) { return chain.SfcAbi().Pack("getUnlockedStake", addr, valID) },
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				return decodeSfcBigInt(chain, "getUnlockedStake", data, &s.Unlocked, &s.Errors.Unlocked)
+			},
+		},
+		{
+			to:   chain.sfcConfig.SFCContract,
+			pack: func() ([]byte, error) { return chain.SfcAbi().Pack("pendingRewards", addr, valID) },
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				return decodeSfcBigInt(chain, "pendingRewards", data, &s.PendingRewards, &s.Errors.PendingRewards)
+			},
+		},
+		{
+			to:   chain.sfcConfig.SFCContract,
+			pack: func() ([]byte, error) { return chain.SfcAbi().Pack("getLockupInfo", addr, valID) },
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				if data == nil {
+					s.Errors.Lock = errSfcSnapshotFieldFailed
+					return nil
+				}
+
+				out := make(map[string]interface{})
+				if err := chain.SfcAbi().UnpackIntoMap(out, "getLockupInfo", data); err != nil {
+					s.Errors.Lock = err
+					return err
+				}
+
+				lockedStake, _ := out["lockedStake"].(*big.Int)
+				fromEpoch, _ := out["fromEpoch"].(*big.Int)
+				endTime, _ := out["endTime"].(*big.Int)
+				duration, _ := out["duration"].(*big.Int)
+				if lockedStake == nil || fromEpoch == nil || endTime == nil || duration == nil {
+					s.Errors.Lock = errSfcSnapshotFieldFailed
+					return s.Errors.Lock
+				}
+
+				s.Lock = types.DelegationLock{
+					LockedAmount:    hexutil.Big(*lockedStake),
+					LockedFromEpoch: hexutil.Uint64(fromEpoch.Uint64()),
+					LockedUntil:     hexutil.Uint64(endTime.Uint64()),
+					Duration:        hexutil.Uint64(duration.Uint64()),
+				}
+				return nil
+			},
+		},
+		{
+			to: chain.sfcConfig.TokenizerContract,
+			pack: func() ([]byte, error) {
+				return chain.sfcTokenizerAbi().Pack("outstandingSCoin", addr, valID)
+			},
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				return decodeSfcTokenizerBigInt(chain, "outstandingSCoin", data, &s.OutstandingSCoin, &s.Errors.OutstandingSCoin)
+			},
+		},
+		{
+			to: chain.sfcConfig.TokenizerContract,
+			pack: func() ([]byte, error) {
+				return chain.sfcTokenizerAbi().Pack("allowedToWithdrawStake", addr, valID)
+			},
+			assign: func(s *types.DelegationSnapshot, data []byte) error {
+				if data == nil {
+					s.Errors.TokenizerUnlocked = errSfcSnapshotFieldFailed
+					return nil
+				}
+				unpacked, err := chain.sfcTokenizerAbi().Unpack("allowedToWithdrawStake", data)
+				if err == nil && len(unpacked) != 1 {
+					err = errSfcSnapshotFieldFailed
+				}
+				if err != nil {
+					s.Errors.TokenizerUnlocked = err
+					return err
+				}
+				ok, _ := unpacked[0].(bool)
+				s.TokenizerUnlocked = ok
+				return nil
+			},
+		},
+	}
+}
+
+// decodeSfcBigInt unpacks a single uint256 return value from the SFC contract ABI.
+func decodeSfcBigInt(chain *ChainBridge, method string, data []byte, dst *hexutil.Big, dstErr *error) error {
+	if data == nil {
+		*dstErr = errSfcSnapshotFieldFailed
+		return nil
+	}
+	unpacked, err := chain.SfcAbi().Unpack(method, data)
+	if err == nil && len(unpacked) != 1 {
+		err = errSfcSnapshotFieldFailed
+	}
+	if err != nil {
+		*dstErr = err
+		return err
+	}
+	amo, ok := unpacked[0].(*big.Int)
+	if !ok {
+		*dstErr = errSfcSnapshotFieldFailed
+		return *dstErr
+	}
+	*dst = hexutil.Big(*amo)
+	return nil
+}
+
+// decodeSfcTokenizerBigInt unpacks a single uint256 return value from the SFC Tokenizer ABI.
+func decodeSfcTokenizerBigInt(chain *ChainBridge, method string, data []byte, dst *hexutil.Big, dstErr *error) error {
+	if data == nil {
+		*dstErr = errSfcSnapshotFieldFailed
+		return nil
+	}
+	unpacked, err := chain.sfcTokenizerAbi().Unpack(method, data)
+	if err == nil && len(unpacked) != 1 {
+		err = errSfcSnapshotFieldFailed
+	}
+	if err != nil {
+		*dstErr = err
+		return err
+	}
+	amo, ok := unpacked[0].(*big.Int)
+	if !ok {
+		*dstErr = errSfcSnapshotFieldFailed
+		return *dstErr
+	}
+	*dst = hexutil.Big(*amo)
+	return nil
+}
+
+// toCallArg builds the JSON-RPC call object expected by eth_call.
+func toCallArg(to common.Address, data []byte) interface{} {
+	return map[string]interface{}{
+		"to":   to,
+		"data": hexutil.Bytes(data),
+	}
+}
+
+// sfcTokenizerAbi returns the parsed SFC Tokenizer contract ABI, caching it on first use the
+// same way SfcAbi() caches the main SFC contract ABI.
+func (chain *ChainBridge) sfcTokenizerAbi() abi.ABI {
+	if chain.sfcTokenizerAbiCache == nil {
+		parsed, err := contracts.SfcTokenizerMetaData.GetAbi()
+		if err != nil {
+			chain.log.Criticalf("failed to parse SFC Tokenizer ABI; %s", err.Error())
+			return abi.ABI{}
+		}
+		chain.sfcTokenizerAbiCache = parsed
+	}
+	return *chain.sfcTokenizerAbiCache
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunkDelegationKeyRanges(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		size  int
+		want  [][2]int
+	}{
+		{"empty", 0, 10, [][2]int{}},
+		{"single chunk", 5, 10, [][2]int{{0, 5}}},
+		{"exact multiple", 6, 3, [][2]int{{0, 3}, {3, 6}}},
+		{"remainder", 7, 3, [][2]int{{0, 3}, {3, 6}, {6, 7}}},
+		{"non-positive size falls back to default", 30, 0, [][2]int{{0, 25}, {25, 30}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chunkDelegationKeyRanges(tt.total, tt.size)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunkDelegationKeyRanges(%d, %d) = %v, want %v", tt.total, tt.size, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"galaxy-graphql/internal/types"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// sfcEventPageSizeMax caps how many delegation events DelegationEvents returns per page.
+const sfcEventPageSizeMax = 200
+
+// delegationEventNames maps the SFC/SfcTokenizer contract event names we care about to the
+// normalized DelegationEventKind GraphQL resolvers work with.
+var delegationEventNames = map[string]types.DelegationEventKind{
+	"Delegated":       types.DelegationEventDelegated,
+	"Undelegated":     types.DelegationEventUndelegated,
+	"LockedUpStake":   types.DelegationEventLockedUpStake,
+	"UnlockedStake":   types.DelegationEventUnlockedStake,
+	"ClaimedRewards":  types.DelegationEventClaimedRewards,
+	"RestakedRewards": types.DelegationEventRestakedRewards,
+}
+
+// delegationTokenizerEventNames mirrors delegationEventNames for the events emitted by the
+// SfcTokenizer contract instead of the main SFC contract.
+var delegationTokenizerEventNames = map[string]types.DelegationEventKind{
+	"MintedSCoin": types.DelegationEventTokenizerMintedSCoin,
+	"Repaid":      types.DelegationEventTokenizerRepaid,
+}
+
+// DelegationEventFilter narrows a delegation event query or subscription to a specific
+// delegator and, optionally, a specific validator. A nil field means "any".
+type DelegationEventFilter struct {
+	Delegator   *common.Address
+	ValidatorID *big.Int
+}
+
+// DelegationEventCheckpoint persists how far a subscriber has consumed the delegation event
+// stream, so a restart can resume instead of replaying or dropping events.
+type DelegationEventCheckpoint interface {
+	// LastBlock returns the last block number fully processed, or 0 if none was processed yet.
+	LastBlock() (uint64, error)
+	// SetLastBlock persists the last block number fully processed.
+	SetLastBlock(block uint64) error
+}
+
+// DelegationEvents returns a cursor-stable, paginated slice of delegation events for the given
+// delegator/validator pair within [fromBlock, toBlock]. A nil cursor starts from the beginning
+// of the range; the returned cursor, when non-nil, should be passed back in to fetch the next page.
+func (chain *ChainBridge) DelegationEvents(
+	addr *common.Address,
+	valID *big.Int,
+	fromBlock uint64,
+	toBlock uint64,
+	cursor *types.DelegationEventCursor,
+	limit int,
+) ([]types.DelegationEvent, *types.DelegationEventCursor, error) {
+	logs, err := chain.filterDelegationLogs(addr, valID, fromBlock, toBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pageLogs, next := delegationEventsPage(logs, cursor, limit)
+
+	page := make([]types.DelegationEvent, 0, len(pageLogs))
+	for _, lg := range pageLogs {
+		ev, err := decodeDelegationLog(chain, lg)
+		if err != nil {
+			chain.log.Debugf("skipping undecodable delegation event log; %s", err.Error())
+			continue
+		}
+		page = append(page, *ev)
+	}
+
+	return page, next, nil
+}
+
+// delegationEventsPage sorts logs into block/index order and slices out the page starting right
+// after cursor (or from the start, if nil), up to limit entries (clamped to sfcEventPageSizeMax).
+// It returns the page's logs and the cursor to resume from, or a nil cursor when the page reaches
+// the end of logs.
+func delegationEventsPage(logs []ethtypes.Log, cursor *types.DelegationEventCursor, limit int) ([]ethtypes.Log, *types.DelegationEventCursor) {
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	start := 0
+	if cursor != nil {
+		start = len(logs)
+		for i, lg := range logs {
+			if lg.BlockNumber > cursor.BlockNumber || (lg.BlockNumber == cursor.BlockNumber && lg.Index > cursor.LogIndex) {
+				start = i
+				break
+			}
+		}
+	}
+
+	if limit <= 0 || limit > sfcEventPageSizeMax {
+		limit = sfcEventPageSizeMax
+	}
+
+	end := start + limit
+	if end > len(logs) {
+		end = len(logs)
+	}
+
+	var next *types.DelegationEventCursor
+	if end < len(logs) {
+		next = &types.DelegationEventCursor{BlockNumber: logs[end-1].BlockNumber, LogIndex: logs[end-1].Index}
+	}
+
+	return logs[start:end], next
+}
+
+// SubscribeDelegationEvents streams live delegation events matching the given filter over
+// eth_subscribe("logs", ...). If cp already has a checkpoint, the gap up to the current chain
+// head is backfilled via FilterLogs before the live stream starts.
+func (chain *ChainBridge) SubscribeDelegationEvents(filter DelegationEventFilter, cp DelegationEventCheckpoint) (<-chan types.DelegationEvent, ethereum.Subscription, error) {
+	query := chain.delegationLogFilterQuery(filter.Delegator, filter.ValidatorID, nil, nil)
+
+	rawLogs := make(chan ethtypes.Log)
+	sub, err := chain.eth.SubscribeFilterLogs(context.Background(), query, rawLogs)
+	if err != nil {
+		chain.log.Errorf("can not subscribe to delegation events; %s", err.Error())
+		return nil, nil, err
+	}
+
+	out := make(chan types.DelegationEvent)
+	go chain.pumpDelegationEvents(rawLogs, sub, out, filter, cp)
+
+	return out, sub, nil
+}
+
+// sendDelegationEvent delivers ev on out, but also watches sub.Err() so a stalled consumer that
+// stopped draining out cannot wedge this goroutine forever: once the subscription is torn down
+// (e.g. via sub.Unsubscribe()), sub.Err() unblocks the select and the send is abandoned. It
+// reports whether the event was actually delivered.
+func sendDelegationEvent(sub ethereum.Subscription, out chan types.DelegationEvent, ev types.DelegationEvent) bool {
+	select {
+	case out <- ev:
+		return true
+	case <-sub.Err():
+		return false
+	}
+}
+
+// delegationEventKey identifies a canonical delegation log by its block, block hash and position,
+// so the same log is never forwarded twice across a backfill, a live subscription and repeated
+// reorg resyncs of the same block, while a log that replaced it after a reorg is still forwarded.
+type delegationEventKey struct {
+	block uint64
+	hash  common.Hash
+	index uint
+}
+
+// delegationEventDedupWindow bounds how many blocks of dedup history pumpDelegationEvents keeps;
+// logs older than this behind the newest live log are no longer deduplicated.
+const delegationEventDedupWindow = 10_000
+
+// pruneDelegationEventSeen drops dedup entries older than delegationEventDedupWindow behind head.
+func pruneDelegationEventSeen(seen map[delegationEventKey]bool, head uint64) {
+	if head <= delegationEventDedupWindow {
+		return
+	}
+	min := head - delegationEventDedupWindow
+	for k := range seen {
+		if k.block < min {
+			delete(seen, k)
+		}
+	}
+}
+
+// pumpDelegationEvents decodes and forwards logs from a live subscription. When the node reports
+// a removal (chain reorg), the removed event itself is forwarded first so consumers can retract
+// it, then the affected block is re-resolved to deliver its now-canonical events. seen dedups
+// canonical logs across the backfill, the live feed and repeated resyncs of the same reorged block.
+func (chain *ChainBridge) pumpDelegationEvents(
+	rawLogs chan ethtypes.Log,
+	sub ethereum.Subscription,
+	out chan types.DelegationEvent,
+	filter DelegationEventFilter,
+	cp DelegationEventCheckpoint,
+) {
+	defer close(out)
+
+	seen := make(map[delegationEventKey]bool)
+
+	if cp != nil {
+		if !chain.backfillDelegationEvents(sub, filter, cp, out, seen) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case err := <-sub.Err():
+			if err != nil {
+				chain.log.Errorf("delegation event subscription ended; %s", err.Error())
+			}
+			return
+		case lg, ok := <-rawLogs:
+			if !ok {
+				return
+			}
+
+			if lg.Removed {
+				ev, err := decodeDelegationLog(chain, lg)
+				if err != nil {
+					chain.log.Debugf("skipping undecodable removed delegation event log; %s", err.Error())
+				} else if !sendDelegationEvent(sub, out, *ev) {
+					return
+				}
+				if !chain.resyncDelegationBlock(sub, lg.BlockNumber, filter, out, seen) {
+					return
+				}
+				continue
+			}
+
+			key := delegationEventKey{block: lg.BlockNumber, hash: lg.BlockHash, index: lg.Index}
+			if seen[key] {
+				continue
+			}
+
+			ev, err := decodeDelegationLog(chain, lg)
+			if err != nil {
+				chain.log.Debugf("skipping undecodable delegation event log; %s", err.Error())
+				continue
+			}
+
+			if !sendDelegationEvent(sub, out, *ev) {
+				return
+			}
+			seen[key] = true
+			pruneDelegationEventSeen(seen, lg.BlockNumber)
+			if cp != nil {
+				if err := cp.SetLastBlock(lg.BlockNumber); err != nil {
+					chain.log.Errorf("failed to persist delegation event checkpoint; %s", err.Error())
+				}
+			}
+		}
+	}
+}
+
+// backfillDelegationEvents replays any delegation events emitted between the checkpoint and the
+// current chain head, used to close the gap left by a subscriber restart. seen is shared with the
+// live feed so a log the subscription already delivered near head isn't replayed a second time.
+// It reports whether the subscriber should keep running.
+func (chain *ChainBridge) backfillDelegationEvents(sub ethereum.Subscription, filter DelegationEventFilter, cp DelegationEventCheckpoint, out chan types.DelegationEvent, seen map[delegationEventKey]bool) bool {
+	last, err := cp.LastBlock()
+	if err != nil {
+		chain.log.Errorf("delegation event checkpoint not available; %s", err.Error())
+		return true
+	}
+	if last == 0 {
+		return true
+	}
+
+	head, err := chain.eth.BlockNumber(context.Background())
+	if err != nil {
+		chain.log.Errorf("can not resolve chain head for delegation event backfill; %s", err.Error())
+		return true
+	}
+	if head <= last {
+		return true
+	}
+
+	logs, err := chain.filterDelegationLogs(filter.Delegator, filter.ValidatorID, last+1, head)
+	if err != nil {
+		chain.log.Errorf("delegation event backfill from block %d failed; %s", last+1, err.Error())
+		return true
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	for _, lg := range logs {
+		key := delegationEventKey{block: lg.BlockNumber, hash: lg.BlockHash, index: lg.Index}
+		if seen[key] {
+			continue
+		}
+
+		ev, err := decodeDelegationLog(chain, lg)
+		if err != nil {
+			continue
+		}
+		if !sendDelegationEvent(sub, out, *ev) {
+			return false
+		}
+		seen[key] = true
+		if err := cp.SetLastBlock(lg.BlockNumber); err != nil {
+			chain.log.Errorf("failed to persist delegation event checkpoint; %s", err.Error())
+		}
+	}
+	return true
+}
+
+// resyncDelegationBlock re-derives the now-canonical delegation events for a block after the node
+// reported one of its logs as removed by a reorg. A reorged block yields one Removed notification
+// per log it contained, so this can be called several times in a row for the same block; seen
+// ensures each canonical log is still only forwarded once. It reports whether the subscriber
+// should keep running.
+func (chain *ChainBridge) resyncDelegationBlock(sub ethereum.Subscription, block uint64, filter DelegationEventFilter, out chan types.DelegationEvent, seen map[delegationEventKey]bool) bool {
+	logs, err := chain.filterDelegationLogs(filter.Delegator, filter.ValidatorID, block, block)
+	if err != nil {
+		chain.log.Errorf("delegation event reorg resync of block %d failed; %s", block, err.Error())
+		return true
+	}
+
+	for _, lg := range logs {
+		key := delegationEventKey{block: lg.BlockNumber, hash: lg.BlockHash, index: lg.Index}
+		if seen[key] {
+			continue
+		}
+
+		ev, err := decodeDelegationLog(chain, lg)
+		if err != nil {
+			continue
+		}
+		if !sendDelegationEvent(sub, out, *ev) {
+			return false
+		}
+		seen[key] = true
+	}
+	return true
+}
+
+// filterDelegationLogs fetches the raw delegation related logs for an optional delegator/validator
+// pair within [fromBlock, toBlock] via FilterLogs, covering both the SFC and SfcTokenizer contracts.
+func (chain *ChainBridge) filterDelegationLogs(addr *common.Address, valID *big.Int, fromBlock, toBlock uint64) ([]ethtypes.Log, error) {
+	query := chain.delegationLogFilterQuery(addr, valID, new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock))
+
+	logs, err := chain.eth.FilterLogs(context.Background(), query)
+	if err != nil {
+		return nil, fmt.Errorf("delegation event log filter failed; %s", err.Error())
+	}
+	return logs, nil
+}
+
+// delegationLogFilterQuery builds the ethereum.FilterQuery shared by DelegationEvents and
+// SubscribeDelegationEvents, pinning on the delegator address and validator ID through indexed
+// topics when given.
+func (chain *ChainBridge) delegationLogFilterQuery(addr *common.Address, valID *big.Int, fromBlock, toBlock *big.Int) ethereum.FilterQuery {
+	var delegatorTopic []common.Hash
+	if addr != nil {
+		delegatorTopic = []common.Hash{common.BytesToHash(addr.Bytes())}
+	}
+
+	var validatorTopic []common.Hash
+	if valID != nil {
+		validatorTopic = []common.Hash{common.BigToHash(valID)}
+	}
+
+	return ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{chain.sfcConfig.SFCContract, chain.sfcConfig.TokenizerContract},
+		Topics:    [][]common.Hash{delegationEventTopics(chain), delegatorTopic, validatorTopic},
+	}
+}
+
+// delegationEventTopics returns the topic0 hashes of every SFC/SfcTokenizer event we index, used
+// as the first element of a FilterQuery's Topics to match any of them in one request.
+func delegationEventTopics(chain *ChainBridge) []common.Hash {
+	topics := make([]common.Hash, 0, len(delegationEventNames)+len(delegationTokenizerEventNames))
+
+	for name := range delegationEventNames {
+		if ev, ok := chain.SfcAbi().Events[name]; ok {
+			topics = append(topics, ev.ID)
+		}
+	}
+	for name := range delegationTokenizerEventNames {
+		if ev, ok := chain.sfcTokenizerAbi().Events[name]; ok {
+			topics = append(topics, ev.ID)
+		}
+	}
+
+	return topics
+}
+
+// decodeDelegationLog normalizes a raw SFC/SfcTokenizer log into a typed types.DelegationEvent.
+func decodeDelegationLog(chain *ChainBridge, lg ethtypes.Log) (*types.DelegationEvent, error) {
+	if len(lg.Topics) < 3 {
+		return nil, fmt.Errorf("delegation event log missing indexed delegator/validator topics")
+	}
+
+	name, eventAbi, kind, err := delegationEventByTopic(chain, lg.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := decodeDelegationAmount(eventAbi, name, lg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DelegationEvent{
+		Kind:        kind,
+		Delegator:   common.BytesToAddress(lg.Topics[1].Bytes()),
+		ValidatorID: new(big.Int).SetBytes(lg.Topics[2].Bytes()).Uint64(),
+		Amount:      amount,
+		BlockNumber: lg.BlockNumber,
+		TxHash:      lg.TxHash,
+		LogIndex:    lg.Index,
+		Removed:     lg.Removed,
+	}, nil
+}
+
+// delegationEventByTopic resolves a log's topic0 back to the originating contract ABI, the
+// matching event name and the normalized DelegationEventKind.
+func delegationEventByTopic(chain *ChainBridge, topic0 common.Hash) (string, abi.ABI, types.DelegationEventKind, error) {
+	for name, kind := range delegationEventNames {
+		if ev, ok := chain.SfcAbi().Events[name]; ok && ev.ID == topic0 {
+			return name, chain.SfcAbi(), kind, nil
+		}
+	}
+	for name, kind := range delegationTokenizerEventNames {
+		if ev, ok := chain.sfcTokenizerAbi().Events[name]; ok && ev.ID == topic0 {
+			return name, chain.sfcTokenizerAbi(), kind, nil
+		}
+	}
+	return "", abi.ABI{}, "", fmt.Errorf("log topic %s is not a known delegation event", topic0.String())
+}
+
+// decodeDelegationAmount unpacks the non-indexed fields of a delegation event log into a single
+// amount. ClaimedRewards/RestakedRewards split their payout into three components; those are
+// summed into one total so GraphQL callers see a single consistent Amount field.
+func decodeDelegationAmount(eventAbi abi.ABI, name string, data []byte) (*big.Int, error) {
+	out := make(map[string]interface{})
+	if err := eventAbi.UnpackIntoMap(out, name, data); err != nil {
+		return nil, fmt.Errorf("delegation event %s data not decodable; %s", name, err.Error())
+	}
+
+	switch name {
+	case "ClaimedRewards", "RestakedRewards":
+		return sumDelegationRewardFields(out), nil
+	default:
+		if v, ok := out["amount"].(*big.Int); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("delegation event %s missing amount field", name)
+	}
+}
+
+// sumDelegationRewardFields adds up a ClaimedRewards/RestakedRewards event's three reward
+// components into the single total exposed as DelegationEvent.Amount.
+func sumDelegationRewardFields(fields map[string]interface{}) *big.Int {
+	total := new(big.Int)
+	for _, field := range []string{"lockupExtraReward", "lockupBaseReward", "unlockedReward"} {
+		if v, ok := fields[field].(*big.Int); ok {
+			total.Add(total, v)
+		}
+	}
+	return total
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"galaxy-graphql/internal/types"
+	"math/big"
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDelegationEventsPage(t *testing.T) {
+	logs := []ethtypes.Log{
+		{BlockNumber: 10, Index: 1},
+		{BlockNumber: 10, Index: 0},
+		{BlockNumber: 12, Index: 0},
+		{BlockNumber: 11, Index: 0},
+	}
+
+	t.Run("nil cursor returns from the start", func(t *testing.T) {
+		page, next := delegationEventsPage(logs, nil, 2)
+		if len(page) != 2 || page[0].BlockNumber != 10 || page[0].Index != 0 || page[1].BlockNumber != 10 || page[1].Index != 1 {
+			t.Fatalf("unexpected page: %+v", page)
+		}
+		if next == nil || next.BlockNumber != 10 || next.LogIndex != 1 {
+			t.Fatalf("unexpected next cursor: %+v", next)
+		}
+	})
+
+	t.Run("cursor resumes after the given position", func(t *testing.T) {
+		cursor := &types.DelegationEventCursor{BlockNumber: 10, LogIndex: 1}
+		page, next := delegationEventsPage(logs, cursor, 10)
+		if len(page) != 2 || page[0].BlockNumber != 11 || page[1].BlockNumber != 12 {
+			t.Fatalf("unexpected page: %+v", page)
+		}
+		if next != nil {
+			t.Fatalf("expected no next cursor, got %+v", next)
+		}
+	})
+
+	t.Run("cursor past all entries returns nothing", func(t *testing.T) {
+		cursor := &types.DelegationEventCursor{BlockNumber: 99, LogIndex: 0}
+		page, next := delegationEventsPage(logs, cursor, 10)
+		if len(page) != 0 || next != nil {
+			t.Fatalf("expected empty page and nil cursor, got page=%+v next=%+v", page, next)
+		}
+	})
+
+	t.Run("limit <= 0 falls back to the page size cap", func(t *testing.T) {
+		page, _ := delegationEventsPage(logs, nil, 0)
+		if len(page) != len(logs) {
+			t.Fatalf("expected all %d logs, got %d", len(logs), len(page))
+		}
+	})
+
+	t.Run("limit above the cap is clamped", func(t *testing.T) {
+		page, _ := delegationEventsPage(logs, nil, sfcEventPageSizeMax+1)
+		if len(page) != len(logs) {
+			t.Fatalf("expected all %d logs, got %d", len(logs), len(page))
+		}
+	})
+}
+
+func TestPruneDelegationEventSeen(t *testing.T) {
+	seen := map[delegationEventKey]bool{
+		{block: 1, index: 0}:      true,
+		{block: 5, index: 0}:      true,
+		{block: 9_999, index: 0}:  true,
+		{block: 10_000, index: 0}: true,
+	}
+
+	pruneDelegationEventSeen(seen, 20_000)
+
+	if len(seen) != 1 {
+		t.Fatalf("expected only the block within the dedup window to survive, got %v", seen)
+	}
+	if !seen[delegationEventKey{block: 10_000, index: 0}] {
+		t.Fatalf("expected block 10000 to survive pruning, got %v", seen)
+	}
+}
+
+func TestSumDelegationRewardFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		want   *big.Int
+	}{
+		{
+			name: "all three components",
+			fields: map[string]interface{}{
+				"lockupExtraReward": big.NewInt(1),
+				"lockupBaseReward":  big.NewInt(2),
+				"unlockedReward":    big.NewInt(3),
+			},
+			want: big.NewInt(6),
+		},
+		{
+			name:   "missing fields contribute nothing",
+			fields: map[string]interface{}{"lockupBaseReward": big.NewInt(5)},
+			want:   big.NewInt(5),
+		},
+		{
+			name:   "empty map sums to zero",
+			fields: map[string]interface{}{},
+			want:   big.NewInt(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sumDelegationRewardFields(tt.fields)
+			if got.Cmp(tt.want) != 0 {
+				t.Fatalf("sumDelegationRewardFields(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
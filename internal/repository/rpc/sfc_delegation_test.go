@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestResolveBlockNumberTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		num        rpc.BlockNumber
+		wantValue  *big.Int
+		wantHeader bool
+	}{
+		{"latest", rpc.LatestBlockNumber, nil, false},
+		{"pending", rpc.PendingBlockNumber, nil, false},
+		{"safe", rpc.SafeBlockNumber, nil, true},
+		{"finalized", rpc.FinalizedBlockNumber, nil, true},
+		{"explicit", rpc.BlockNumber(42), big.NewInt(42), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, needsHeader := resolveBlockNumberTag(tt.num)
+			if needsHeader != tt.wantHeader {
+				t.Fatalf("resolveBlockNumberTag(%s) needsHeader = %v, want %v", tt.name, needsHeader, tt.wantHeader)
+			}
+			if (value == nil) != (tt.wantValue == nil) {
+				t.Fatalf("resolveBlockNumberTag(%s) value = %v, want %v", tt.name, value, tt.wantValue)
+			}
+			if value != nil && tt.wantValue != nil && value.Cmp(tt.wantValue) != 0 {
+				t.Fatalf("resolveBlockNumberTag(%s) value = %v, want %v", tt.name, value, tt.wantValue)
+			}
+		})
+	}
+}
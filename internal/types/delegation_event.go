@@ -0,0 +1,48 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DelegationEventKind identifies which SFC/SfcTokenizer contract event a DelegationEvent
+// was decoded from.
+type DelegationEventKind string
+
+// Recognized delegation event kinds, matching the SFC and SfcTokenizer contract event names.
+const (
+	DelegationEventDelegated            DelegationEventKind = "DELEGATED"
+	DelegationEventUndelegated          DelegationEventKind = "UNDELEGATED"
+	DelegationEventLockedUpStake        DelegationEventKind = "LOCKED_UP_STAKE"
+	DelegationEventUnlockedStake        DelegationEventKind = "UNLOCKED_STAKE"
+	DelegationEventClaimedRewards       DelegationEventKind = "CLAIMED_REWARDS"
+	DelegationEventRestakedRewards      DelegationEventKind = "RESTAKED_REWARDS"
+	DelegationEventTokenizerMintedSCoin DelegationEventKind = "TOKENIZER_MINTED_SCOIN"
+	DelegationEventTokenizerRepaid      DelegationEventKind = "TOKENIZER_REPAID"
+)
+
+// DelegationEvent is a normalized, typed representation of a single SFC/SfcTokenizer delegation
+// related log entry, independent of whether it was observed through a live subscription or
+// a historical FilterLogs backfill.
+type DelegationEvent struct {
+	Kind        DelegationEventKind
+	Delegator   common.Address
+	ValidatorID uint64
+	Amount      *big.Int
+
+	BlockNumber uint64
+	TxHash      common.Hash
+	LogIndex    uint
+
+	// Removed is true when the event arrived as part of a chain reorg removing a previously
+	// delivered log; consumers should retract the matching event rather than append it.
+	Removed bool
+}
+
+// DelegationEventCursor identifies a position in the delegation event stream so callers can
+// resume a paginated DelegationEvents query where a previous page left off.
+type DelegationEventCursor struct {
+	BlockNumber uint64
+	LogIndex    uint
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DelegationKey identifies a single delegator/validator pair addressed by a batched
+// delegation snapshot lookup.
+type DelegationKey struct {
+	Address     common.Address
+	ValidatorID hexutil.Big
+}
+
+// DelegationSnapshot aggregates the staking/delegation view values of a single delegator/validator
+// pair collected through one batched RPC round trip. Fields are left at their zero value when the
+// corresponding call failed or reverted; see Errors for the per-field detail.
+type DelegationSnapshot struct {
+	Key DelegationKey
+
+	Staked            hexutil.Big
+	Locked            hexutil.Big
+	Unlocked          hexutil.Big
+	PendingRewards    hexutil.Big
+	Lock              DelegationLock
+	OutstandingSCoin  hexutil.Big
+	TokenizerUnlocked bool
+
+	Errors DelegationSnapshotErrors
+}
+
+// DelegationSnapshotErrors carries the per-field error, if any, encountered while resolving
+// the matching field of a DelegationSnapshot. A nil entry means the field decoded cleanly.
+type DelegationSnapshotErrors struct {
+	Staked            error
+	Locked            error
+	Unlocked          error
+	PendingRewards    error
+	Lock              error
+	OutstandingSCoin  error
+	TokenizerUnlocked error
+}